@@ -0,0 +1,92 @@
+// Package analyze parses Go source files and finds the structs and lock
+// patterns that gobackend turns into the intermediate representation
+// dotViewer renders to dot. It also records
+// run statistics (files parsed, structs found, ...) via the stats package
+// so callers can observe a large run before the final graph is ready.
+package analyze
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+
+	"github.com/Stianlars1/dotViewer/stats"
+)
+
+// Counter names bumped by the analyzer. Exporters (Prometheus, JSON dump)
+// look these up by name through the shared StatManager.
+const (
+	CounterFilesParsed      = "files_parsed"
+	CounterStructsFound     = "structs_found"
+	CounterMutexFieldsFound = "mutex_fields_found"
+)
+
+// Analyzer parses Go source files into a FileAnalysis (AST plus type info).
+// It reports progress through a StatManager so long, multi-file runs are
+// observable before the graph is final.
+type Analyzer struct {
+	Stats stats.StatManager
+}
+
+// NewAnalyzer returns an Analyzer that reports counters on sm. If sm is
+// nil, a fresh in-memory StatManager is created.
+func NewAnalyzer(sm stats.StatManager) *Analyzer {
+	if sm == nil {
+		sm = stats.NewStatManager()
+	}
+	return &Analyzer{Stats: sm}
+}
+
+// FileAnalysis is what AnalyzeFile produces for a single source file: its
+// AST plus whatever type information was resolved along the way. Info is
+// best-effort — type-checking a lone file can fail to resolve everything,
+// so callers (e.g. FindConcurrency) should tolerate a partially-filled
+// Info rather than require a clean type-check.
+type FileAnalysis struct {
+	File *ast.File
+	Info *types.Info
+}
+
+// AnalyzeFile parses a single Go source file, updating the analyzer's
+// counters as it discovers structs and mutex-guarded fields.
+func (a *Analyzer) AnalyzeFile(path string, src []byte) (*FileAnalysis, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	a.bump(CounterFilesParsed, 1)
+
+	info, _ := typeCheck(fset, file)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		ts, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok {
+			return true
+		}
+		a.bump(CounterStructsFound, 1)
+		for _, field := range st.Fields.List {
+			if _, ok := mutexFieldName(field, info); ok {
+				a.bump(CounterMutexFieldsFound, 1)
+			}
+		}
+		return true
+	})
+
+	return &FileAnalysis{File: file, Info: info}, nil
+}
+
+// bump registers the counter on first use and adds delta to it.
+func (a *Analyzer) bump(name string, delta int64) {
+	c, err := a.Stats.RegisterCounter(name)
+	if err != nil {
+		return
+	}
+	c.Add(delta)
+}
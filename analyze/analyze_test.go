@@ -0,0 +1,46 @@
+package analyze
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestAnalyzeFileCountsTestFixture exercises AnalyzeFile against the
+// repo's own E2E fixture, the same sample UserStore the backlog requests
+// keep referencing.
+func TestAnalyzeFileCountsTestFixture(t *testing.T) {
+	path := filepath.Join("..", "TestFiles", "test.go")
+	src, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	a := NewAnalyzer(nil)
+	fa, err := a.AnalyzeFile(path, src)
+	if err != nil {
+		t.Fatalf("AnalyzeFile: %v", err)
+	}
+	if fa.File == nil {
+		t.Fatal("AnalyzeFile returned a nil File")
+	}
+
+	tests := []struct {
+		counter string
+		want    int64
+	}{
+		{CounterFilesParsed, 1},
+		{CounterStructsFound, 2},     // User, UserStore
+		{CounterMutexFieldsFound, 1}, // UserStore.mu
+	}
+	for _, tt := range tests {
+		c := a.Stats.GetCounter(tt.counter)
+		if c == nil {
+			t.Errorf("counter %q was never registered", tt.counter)
+			continue
+		}
+		if got := c.Value(); got != tt.want {
+			t.Errorf("counter %q = %d, want %d", tt.counter, got, tt.want)
+		}
+	}
+}
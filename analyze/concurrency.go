@@ -0,0 +1,235 @@
+package analyze
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// MethodLockKind classifies how a method guards the mutex-bearing struct
+// it's declared on.
+type MethodLockKind int
+
+const (
+	// LockKindNone means no Lock or RLock call on the struct's lock was
+	// found among the method's top-level statements.
+	LockKindNone MethodLockKind = iota
+	// LockKindRead means the method is wrapped in RLock/RUnlock.
+	LockKindRead
+	// LockKindWrite means the method is wrapped in Lock/Unlock.
+	LockKindWrite
+)
+
+func (k MethodLockKind) String() string {
+	switch k {
+	case LockKindRead:
+		return "reads"
+	case LockKindWrite:
+		return "writes"
+	default:
+		return "none"
+	}
+}
+
+// DotStyle returns the edge style dotViewer should use when rendering this
+// method's call edges: dashed for read-locked methods, bold for
+// write-locked ones, solid otherwise.
+func (k MethodLockKind) DotStyle() string {
+	switch k {
+	case LockKindRead:
+		return "dashed"
+	case LockKindWrite:
+		return "bold"
+	default:
+		return "solid"
+	}
+}
+
+// MethodLockInfo records how a single method guards its receiver's lock.
+type MethodLockInfo struct {
+	Name string
+	Kind MethodLockKind
+	// DeferredUnlock is true when the unlock call is made via defer
+	// immediately after the lock call, the pattern this repo expects.
+	DeferredUnlock bool
+}
+
+// Concurrency describes a mutex-guarded struct and how each of its methods
+// uses that lock. Frontends other than dot (JSON, TUI) consume this
+// directly instead of re-walking the AST themselves.
+type Concurrency struct {
+	Struct  string
+	Field   string
+	Methods []MethodLockInfo
+}
+
+// FindConcurrency walks file looking for structs that embed or declare a
+// sync.Mutex, sync.RWMutex, or sync.Map field, then classifies every
+// method on that struct by how it uses the lock. For an embedded lock,
+// only calls written through the field name (s.RWMutex.Lock()) are
+// recognized; promoted calls (s.Lock()) are not, so such methods are left
+// out of Methods. info, typically from
+// typeCheck, lets the field detection resolve sync imported under an
+// alias; pass nil to fall back to matching the literal "sync" selector.
+func FindConcurrency(file *ast.File, info *types.Info) []Concurrency {
+	guarded := map[string]string{} // struct name -> guarding field name
+
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			for _, field := range st.Fields.List {
+				if name, ok := mutexFieldName(field, info); ok {
+					guarded[ts.Name.Name] = name
+					break
+				}
+			}
+		}
+	}
+
+	if len(guarded) == 0 {
+		return nil
+	}
+
+	results := make(map[string]*Concurrency, len(guarded))
+	for structName, field := range guarded {
+		results[structName] = &Concurrency{Struct: structName, Field: field}
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+			continue
+		}
+		recvName := receiverTypeName(fn.Recv.List[0].Type)
+		c, ok := results[recvName]
+		if !ok {
+			continue
+		}
+		kind, deferred := classifyLockUsage(fn.Body, c.Field)
+		if kind == LockKindNone {
+			continue
+		}
+		c.Methods = append(c.Methods, MethodLockInfo{
+			Name:           fn.Name.Name,
+			Kind:           kind,
+			DeferredUnlock: deferred,
+		})
+	}
+
+	out := make([]Concurrency, 0, len(results))
+	for _, c := range results {
+		out = append(out, *c)
+	}
+	return out
+}
+
+// receiverTypeName strips the pointer off a method receiver's type
+// expression and returns the bare type name.
+func receiverTypeName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// classifyLockUsage looks through the top-level statements of body for the
+// first field.Lock() or field.RLock() call and reports the resulting lock
+// kind, plus whether the unlock half was a defer right after the lock
+// call. Calls nested inside blocks (if, for, closures) are not examined.
+func classifyLockUsage(body *ast.BlockStmt, field string) (kind MethodLockKind, deferred bool) {
+	if body == nil {
+		return LockKindNone, false
+	}
+
+	for i, stmt := range body.List {
+		call, ok := lockCall(stmt, field)
+		if !ok {
+			continue
+		}
+		switch call {
+		case "Lock":
+			kind = LockKindWrite
+		case "RLock":
+			kind = LockKindRead
+		default:
+			continue
+		}
+		deferred = i+1 < len(body.List) && isDeferredUnlock(body.List[i+1], field, call)
+		return kind, deferred
+	}
+	return LockKindNone, false
+}
+
+// lockCall reports the method name (Lock/RLock) if stmt is a bare call to
+// field.<method>().
+func lockCall(stmt ast.Stmt, field string) (string, bool) {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return "", false
+	}
+	call, ok := exprStmt.X.(*ast.CallExpr)
+	if !ok {
+		return "", false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	if !isFieldSelector(sel.X, field) {
+		return "", false
+	}
+	switch sel.Sel.Name {
+	case "Lock", "RLock":
+		return sel.Sel.Name, true
+	default:
+		return "", false
+	}
+}
+
+// isDeferredUnlock reports whether stmt is `defer field.Unlock()` (or
+// RUnlock, matching lockMethod).
+func isDeferredUnlock(stmt ast.Stmt, field, lockMethod string) bool {
+	deferStmt, ok := stmt.(*ast.DeferStmt)
+	if !ok {
+		return false
+	}
+	sel, ok := deferStmt.Call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	if !isFieldSelector(sel.X, field) {
+		return false
+	}
+	want := "Unlock"
+	if lockMethod == "RLock" {
+		want = "RUnlock"
+	}
+	return sel.Sel.Name == want
+}
+
+// isFieldSelector reports whether expr is `s.field` or a bare identifier
+// named field. A promoted call on an embedded lock (s.Lock()) has the
+// receiver as expr and does not match.
+func isFieldSelector(expr ast.Expr, field string) bool {
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		return e.Sel.Name == field
+	case *ast.Ident:
+		return e.Name == field
+	default:
+		return false
+	}
+}
@@ -0,0 +1,189 @@
+package analyze
+
+import (
+	"go/parser"
+	"go/token"
+	"sort"
+	"testing"
+)
+
+// parseAndCheck parses src as a standalone file and best-effort
+// type-checks it, the same way AnalyzeFile does.
+func parseAndCheck(t *testing.T, src string) *FileAnalysis {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, 0)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	info, err := typeCheck(fset, file)
+	if err != nil {
+		t.Fatalf("typeCheck: %v", err)
+	}
+	return &FileAnalysis{File: file, Info: info}
+}
+
+func methodNames(c Concurrency) []string {
+	names := make([]string, len(c.Methods))
+	for i, m := range c.Methods {
+		names[i] = m.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestFindConcurrencyAliasedSyncImport(t *testing.T) {
+	const src = `package p
+
+import s "sync"
+
+type Store struct {
+	mu    s.RWMutex
+	items map[int]int
+}
+
+func (st *Store) Get(id int) int {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.items[id]
+}
+
+func (st *Store) Set(id, v int) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.items[id] = v
+}
+`
+	fa := parseAndCheck(t, src)
+	got := FindConcurrency(fa.File, fa.Info)
+	if len(got) != 1 {
+		t.Fatalf("FindConcurrency returned %d structs, want 1 (aliased sync import should still resolve)", len(got))
+	}
+	c := got[0]
+	if c.Field != "mu" {
+		t.Errorf("Field = %q, want %q", c.Field, "mu")
+	}
+	if names := methodNames(c); len(names) != 2 {
+		t.Errorf("Methods = %v, want Get and Set", names)
+	}
+}
+
+func TestFindConcurrencyMultipleMutexFields(t *testing.T) {
+	// Documents current behavior: only the first mutex-typed field
+	// encountered guards the struct, matching how classifyLockUsage
+	// only ever checks a single field name.
+	const src = `package p
+
+import "sync"
+
+type Store struct {
+	mu    sync.RWMutex
+	mu2   sync.Mutex
+	items map[int]int
+}
+
+func (st *Store) Get(id int) int {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.items[id]
+}
+`
+	fa := parseAndCheck(t, src)
+	got := FindConcurrency(fa.File, fa.Info)
+	if len(got) != 1 {
+		t.Fatalf("FindConcurrency returned %d structs, want 1", len(got))
+	}
+	if got[0].Field != "mu" {
+		t.Errorf("Field = %q, want %q (first field wins)", got[0].Field, "mu")
+	}
+}
+
+func TestFindConcurrencyReadAndWriteMethods(t *testing.T) {
+	const src = `package p
+
+import "sync"
+
+type Store struct {
+	mu    sync.RWMutex
+	items map[int]int
+}
+
+func (st *Store) Get(id int) int {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.items[id]
+}
+
+func (st *Store) Set(id, v int) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.items[id] = v
+}
+
+func (st *Store) Noop() {}
+`
+	fa := parseAndCheck(t, src)
+	got := FindConcurrency(fa.File, fa.Info)
+	if len(got) != 1 {
+		t.Fatalf("FindConcurrency returned %d structs, want 1", len(got))
+	}
+	c := got[0]
+	if names := methodNames(c); len(names) != 2 || names[0] != "Get" || names[1] != "Set" {
+		t.Errorf("Methods = %v, want [Get Set] (Noop never touches the lock)", names)
+	}
+	for _, m := range c.Methods {
+		switch m.Name {
+		case "Get":
+			if m.Kind != LockKindRead || !m.DeferredUnlock {
+				t.Errorf("Get: Kind=%v DeferredUnlock=%v, want LockKindRead/true", m.Kind, m.DeferredUnlock)
+			}
+		case "Set":
+			if m.Kind != LockKindWrite || !m.DeferredUnlock {
+				t.Errorf("Set: Kind=%v DeferredUnlock=%v, want LockKindWrite/true", m.Kind, m.DeferredUnlock)
+			}
+		}
+	}
+}
+
+func TestFindConcurrencySyncMapNoExplicitLocking(t *testing.T) {
+	const src = `package p
+
+import "sync"
+
+type Store struct {
+	items sync.Map
+}
+
+func (st *Store) Get(id int) (any, bool) {
+	return st.items.Load(id)
+}
+`
+	fa := parseAndCheck(t, src)
+	got := FindConcurrency(fa.File, fa.Info)
+	if len(got) != 1 {
+		t.Fatalf("FindConcurrency returned %d structs, want 1 (sync.Map field should still be detected)", len(got))
+	}
+	c := got[0]
+	if c.Field != "items" {
+		t.Errorf("Field = %q, want %q", c.Field, "items")
+	}
+	if len(c.Methods) != 0 {
+		t.Errorf("Methods = %v, want none (sync.Map methods don't use Lock/Unlock)", methodNames(c))
+	}
+}
+
+func TestFindConcurrencyNoMutex(t *testing.T) {
+	const src = `package p
+
+type Plain struct {
+	Name string
+}
+
+func (p *Plain) Hello() string { return p.Name }
+`
+	fa := parseAndCheck(t, src)
+	got := FindConcurrency(fa.File, fa.Info)
+	if len(got) != 0 {
+		t.Errorf("FindConcurrency returned %d structs, want 0", len(got))
+	}
+}
@@ -0,0 +1,84 @@
+package analyze
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/token"
+	"go/types"
+)
+
+// typeCheck type-checks a single, self-contained file and returns whatever
+// type information it manages to resolve. Type-checking one file in
+// isolation can legitimately fail (unresolvable imports, a package that
+// spans multiple files) — callers should still use a non-nil Info in that
+// case, since expressions it did resolve are more reliable than
+// string-matching identifiers.
+func typeCheck(fset *token.FileSet, file *ast.File) (*types.Info, error) {
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{Importer: importer.Default(), Error: func(error) {}}
+	_, err := conf.Check(file.Name.Name, fset, []*ast.File{file}, info)
+	return info, err
+}
+
+// mutexFieldName reports field's name if it's a sync.Mutex, sync.RWMutex,
+// or sync.Map, embedded or named. It resolves the field's type through
+// info when available, so it still works when sync is imported under an
+// alias (import s "sync"); if info has nothing for this field (e.g.
+// type-checking failed), it falls back to matching the literal "sync"
+// selector.
+func mutexFieldName(field *ast.Field, info *types.Info) (string, bool) {
+	if name, ok := mutexFieldNameFromType(field, info); ok {
+		return name, true
+	}
+	return mutexFieldNameFromSyntax(field)
+}
+
+func mutexFieldNameFromType(field *ast.Field, info *types.Info) (string, bool) {
+	if info == nil {
+		return "", false
+	}
+	t := info.TypeOf(field.Type)
+	named, ok := t.(*types.Named)
+	if !ok {
+		return "", false
+	}
+	obj := named.Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != "sync" {
+		return "", false
+	}
+	if !isMutexTypeName(obj.Name()) {
+		return "", false
+	}
+	if len(field.Names) == 0 {
+		return obj.Name(), true
+	}
+	return field.Names[0].Name, true
+}
+
+func mutexFieldNameFromSyntax(field *ast.Field) (string, bool) {
+	sel, ok := field.Type.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	pkgIdent, ok := sel.X.(*ast.Ident)
+	if !ok || pkgIdent.Name != "sync" || !isMutexTypeName(sel.Sel.Name) {
+		return "", false
+	}
+	if len(field.Names) == 0 {
+		return sel.Sel.Name, true
+	}
+	return field.Names[0].Name, true
+}
+
+func isMutexTypeName(name string) bool {
+	switch name {
+	case "Mutex", "RWMutex", "Map":
+		return true
+	default:
+		return false
+	}
+}
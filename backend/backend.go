@@ -0,0 +1,44 @@
+// Package backend defines the pluggable language front end dotViewer
+// parses source through. Each language (Go, shell, ...) registers a
+// Backend keyed by the file extensions it handles; the analyzer entry
+// point picks a backend per file instead of assuming Go.
+package backend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Stianlars1/dotViewer/ir"
+)
+
+// Backend turns a single source file into the shared ir.Unit
+// representation. Implementations must be safe to reuse across files.
+type Backend interface {
+	// Extensions returns the file extensions this backend handles,
+	// including the leading dot (e.g. ".go").
+	Extensions() []string
+	// Parse parses src, read from path, into an ir.Unit.
+	Parse(ctx context.Context, path string, src []byte) (*ir.Unit, error)
+}
+
+// registry maps a file extension to the Backend that handles it.
+var registry = map[string]Backend{}
+
+// Register makes b available for each of its Extensions. Later
+// registrations for the same extension replace earlier ones, mirroring
+// how database/sql drivers register themselves.
+func Register(b Backend) {
+	for _, ext := range b.Extensions() {
+		registry[ext] = b
+	}
+}
+
+// For returns the Backend registered for ext (including the leading dot),
+// or an error if none is registered.
+func For(ext string) (Backend, error) {
+	b, ok := registry[ext]
+	if !ok {
+		return nil, fmt.Errorf("backend: no backend registered for extension %q", ext)
+	}
+	return b, nil
+}
@@ -0,0 +1,37 @@
+package backend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Stianlars1/dotViewer/ir"
+)
+
+type stubBackend struct {
+	exts []string
+}
+
+func (s stubBackend) Extensions() []string { return s.exts }
+
+func (s stubBackend) Parse(ctx context.Context, path string, src []byte) (*ir.Unit, error) {
+	return &ir.Unit{Path: path}, nil
+}
+
+func TestRegisterAndFor(t *testing.T) {
+	b := stubBackend{exts: []string{".stub"}}
+	Register(b)
+
+	got, err := For(".stub")
+	if err != nil {
+		t.Fatalf("For(.stub): %v", err)
+	}
+	if len(got.Extensions()) != 1 || got.Extensions()[0] != ".stub" {
+		t.Errorf("For(.stub) returned a different backend than was registered: %+v", got)
+	}
+}
+
+func TestForUnregisteredExtension(t *testing.T) {
+	if _, err := For(".definitely-not-registered"); err == nil {
+		t.Error("For(unregistered extension) should error")
+	}
+}
@@ -0,0 +1,77 @@
+// Package gobackend is the backend.Backend for Go source, wrapping the
+// existing analyze package so its struct/concurrency passes feed the
+// shared ir.Unit representation.
+package gobackend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Stianlars1/dotViewer/analyze"
+	"github.com/Stianlars1/dotViewer/backend"
+	"github.com/Stianlars1/dotViewer/ir"
+)
+
+// init registers the default Go backend, mirroring how database/sql
+// drivers self-register from their own init. Importing this package for
+// its side effect (`_ "github.com/Stianlars1/dotViewer/backend/gobackend"`)
+// is enough to make backend.For(".go") resolve.
+func init() {
+	backend.Register(New(nil))
+}
+
+// Backend parses .go files via the analyze package.
+type Backend struct {
+	analyzer *analyze.Analyzer
+}
+
+// New returns a Go Backend that reports counters through a.
+func New(a *analyze.Analyzer) *Backend {
+	if a == nil {
+		a = analyze.NewAnalyzer(nil)
+	}
+	return &Backend{analyzer: a}
+}
+
+// Extensions implements backend.Backend.
+func (b *Backend) Extensions() []string {
+	return []string{".go"}
+}
+
+// Parse implements backend.Backend.
+func (b *Backend) Parse(ctx context.Context, path string, src []byte) (*ir.Unit, error) {
+	fa, err := b.analyzer.AnalyzeFile(path, src)
+	if err != nil {
+		return nil, fmt.Errorf("gobackend: %w", err)
+	}
+
+	unit := &ir.Unit{Path: path}
+	for _, c := range analyze.FindConcurrency(fa.File, fa.Info) {
+		summaryID := c.Struct + "#concurrency"
+		unit.Nodes = append(unit.Nodes, ir.Node{
+			ID:   summaryID,
+			Name: fmt.Sprintf("%s (guards %s)", c.Struct, c.Field),
+			Kind: ir.NodeFunction,
+			Meta: map[string]string{"kind": "concurrency_summary"},
+		})
+		for _, m := range c.Methods {
+			methodID := c.Struct + "." + m.Name
+			unit.Nodes = append(unit.Nodes, ir.Node{
+				ID:   methodID,
+				Name: m.Name,
+				Kind: ir.NodeFunction,
+				Meta: map[string]string{
+					"lock_kind":       m.Kind.String(),
+					"deferred_unlock": fmt.Sprintf("%t", m.DeferredUnlock),
+				},
+			})
+			unit.Edges = append(unit.Edges, ir.Edge{
+				From: methodID,
+				To:   summaryID,
+				Meta: map[string]string{"style": m.Kind.DotStyle()},
+			})
+		}
+	}
+
+	return unit, nil
+}
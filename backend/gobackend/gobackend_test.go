@@ -0,0 +1,50 @@
+package gobackend_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Stianlars1/dotViewer/backend"
+	_ "github.com/Stianlars1/dotViewer/backend/gobackend"
+)
+
+// TestGoBackendRegistersItself proves that importing this package for its
+// side effect (a blank import, or any import reached before main runs) is
+// enough to make backend.For(".go") resolve — Register is no longer dead
+// code. cmd/dotviewer relies on the same init to seed the registry, then
+// re-registers its own instance wired to its StatManager.
+func TestGoBackendRegistersItself(t *testing.T) {
+	b, err := backend.For(".go")
+	if err != nil {
+		t.Fatalf("backend.For(\".go\"): %v", err)
+	}
+
+	path := filepath.Join("..", "..", "TestFiles", "test.go")
+	src, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	unit, err := b.Parse(context.Background(), path, src)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	var foundSummary, foundMethod bool
+	for _, n := range unit.Nodes {
+		if n.Meta["kind"] == "concurrency_summary" {
+			foundSummary = true
+		}
+		if n.Meta["lock_kind"] == "writes" {
+			foundMethod = true
+		}
+	}
+	if !foundSummary {
+		t.Error("Parse did not emit a concurrency summary node for UserStore")
+	}
+	if !foundMethod {
+		t.Error("Parse did not emit a write-locked method node for UserStore.Add")
+	}
+}
@@ -0,0 +1,180 @@
+// Package shbackend is the backend.Backend for shell scripts, so install
+// scripts, Dockerfile ENTRYPOINTs, and Bash helpers show up in the same
+// dependency graph as the Go code that calls them.
+package shbackend
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"mvdan.cc/sh/v3/syntax"
+
+	"github.com/Stianlars1/dotViewer/backend"
+	"github.com/Stianlars1/dotViewer/ir"
+)
+
+// init registers the default shell backend; see gobackend's init for why
+// this is a side-effect import rather than an explicit wiring call.
+func init() {
+	backend.Register(New())
+}
+
+// Backend parses .sh/.bash files with mvdan.cc/sh.
+//
+// Call targets are resolved syntactically, by taking the first word of
+// each CallExpr — not dynamically via mvdan.cc/sh/v3/interp. Calls inside
+// command substitutions and pipelines are found, since the walk covers the
+// whole syntax tree, but a variable used as a command ($cmd) won't resolve
+// to the function node it names at runtime. Tracking that would mean interpreting the script
+// (interp.Runner) rather than just parsing it, which is a larger, riskier
+// change (side effects, scripts that don't terminate) left for later.
+type Backend struct {
+	parser *syntax.Parser
+}
+
+// New returns a shell Backend.
+func New() *Backend {
+	return &Backend{parser: syntax.NewParser()}
+}
+
+// Extensions implements backend.Backend.
+func (b *Backend) Extensions() []string {
+	return []string{".sh", ".bash"}
+}
+
+// Parse implements backend.Backend. It emits one node per defined
+// function, plus edges for call sites that resolve to one of those
+// functions and for sourced files, and flags suspicious patterns
+// (unquoted expansions, a missing shebang) as node metadata so the dot
+// output can color them.
+//
+// Calls to anything other than a function defined in this file — external
+// binaries, shell builtins (echo, cd, export, ...), calls through a
+// variable — are left out of the edge set rather than pointed at a node
+// that doesn't exist, since call-target resolution here is syntactic
+// (see Backend's doc comment).
+func (b *Backend) Parse(ctx context.Context, path string, src []byte) (*ir.Unit, error) {
+	file, err := b.parser.Parse(bytes.NewReader(src), path)
+	if err != nil {
+		return nil, fmt.Errorf("shbackend: %w", err)
+	}
+
+	unit := &ir.Unit{Path: path}
+
+	fileID := path
+	fileMeta := map[string]string{}
+	if !hasShebang(src) {
+		fileMeta["missing_shebang"] = "true"
+	}
+	unit.Nodes = append(unit.Nodes, ir.Node{
+		ID:   fileID,
+		Name: path,
+		Kind: ir.NodeFile,
+		Meta: fileMeta,
+	})
+
+	// Collect every defined function before looking at call sites, so a
+	// function called before its own definition (textually) still
+	// resolves.
+	funcs := map[string]bool{}
+	syntax.Walk(file, func(node syntax.Node) bool {
+		if fn, ok := node.(*syntax.FuncDecl); ok {
+			funcs[fn.Name.Value] = true
+		}
+		return true
+	})
+	for name := range funcs {
+		unit.Nodes = append(unit.Nodes, ir.Node{
+			ID:   path + "#" + name,
+			Name: name,
+			Kind: ir.NodeFunction,
+		})
+	}
+
+	// Sourced files get a NodeFile node of their own, once each, so source
+	// edges point at a node in this Unit.
+	fileNodes := map[string]bool{fileID: true}
+	syntax.Walk(file, func(node syntax.Node) bool {
+		call, ok := node.(*syntax.CallExpr)
+		if !ok {
+			return true
+		}
+		if len(call.Args) == 0 {
+			return true
+		}
+		name := wordString(call.Args[0])
+		switch {
+		case name == "source" || name == ".":
+			if len(call.Args) > 1 {
+				target := wordString(call.Args[1])
+				if !fileNodes[target] {
+					fileNodes[target] = true
+					unit.Nodes = append(unit.Nodes, ir.Node{
+						ID:   target,
+						Name: target,
+						Kind: ir.NodeFile,
+					})
+				}
+				unit.Edges = append(unit.Edges, ir.Edge{
+					From: fileID,
+					To:   target,
+					Meta: map[string]string{"kind": "source"},
+				})
+			}
+		case funcs[name]:
+			unit.Edges = append(unit.Edges, ir.Edge{
+				From: fileID,
+				To:   path + "#" + name,
+				Meta: map[string]string{"kind": "call"},
+			})
+		}
+		if hasUnquotedExpansion(call) {
+			unit.Nodes = append(unit.Nodes, ir.Node{
+				ID:   fmt.Sprintf("%s#call@%d", path, call.Pos().Offset()),
+				Name: name,
+				Kind: ir.NodeFunction,
+				Meta: map[string]string{"unquoted_expansion": "true"},
+			})
+		}
+		return true
+	})
+
+	return unit, nil
+}
+
+// hasShebang reports whether src starts with a "#!" line, ignoring any
+// leading spaces and tabs.
+func hasShebang(src []byte) bool {
+	return bytes.HasPrefix(bytes.TrimLeft(src, " \t"), []byte("#!"))
+}
+
+// wordString renders a syntax.Word as plain text, best-effort, for use as
+// a node/edge identifier.
+func wordString(w *syntax.Word) string {
+	var sb strings.Builder
+	syntax.NewPrinter().Print(&sb, w)
+	return strings.TrimSpace(sb.String())
+}
+
+// hasUnquotedExpansion reports whether call directly contains a
+// parameter expansion (e.g. $1, $FOO) that isn't wrapped in a
+// syntax.DblQuoted, the classic word-splitting/globbing footgun.
+func hasUnquotedExpansion(call *syntax.CallExpr) bool {
+	found := false
+	syntax.Walk(call, func(node syntax.Node) bool {
+		if found {
+			return false
+		}
+		if _, ok := node.(*syntax.DblQuoted); ok {
+			return false // don't descend into quoted parts
+		}
+		if _, ok := node.(*syntax.ParamExp); ok {
+			found = true
+			return false
+		}
+		return true
+	})
+	return found
+}
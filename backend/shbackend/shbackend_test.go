@@ -0,0 +1,141 @@
+package shbackend_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Stianlars1/dotViewer/backend"
+	_ "github.com/Stianlars1/dotViewer/backend/shbackend"
+	"github.com/Stianlars1/dotViewer/ir"
+)
+
+// TestShBackendRegistersItself proves importing the package for its side
+// effect is enough to make backend.For(".sh") resolve.
+func TestShBackendRegistersItself(t *testing.T) {
+	b, err := backend.For(".sh")
+	if err != nil {
+		t.Fatalf("backend.For(\".sh\"): %v", err)
+	}
+
+	const src = `#!/bin/bash
+greet() {
+  echo "hi $1"
+}
+
+main() {
+  greet World
+  echo $UNQUOTED
+}
+
+main
+`
+	unit, err := b.Parse(context.Background(), "install.sh", []byte(src))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	nodeIDs := map[string]bool{}
+	for _, n := range unit.Nodes {
+		nodeIDs[n.ID] = true
+	}
+	for _, want := range []string{"install.sh#greet", "install.sh#main"} {
+		if !nodeIDs[want] {
+			t.Errorf("missing function node %q, got nodes %v", want, nodeIDs)
+		}
+	}
+
+	var sawCallEdge, sawUnquoted bool
+	for _, e := range unit.Edges {
+		if e.Meta["kind"] == "call" && e.To == "install.sh#greet" {
+			sawCallEdge = true
+		}
+	}
+	for _, n := range unit.Nodes {
+		if n.Meta["unquoted_expansion"] == "true" {
+			sawUnquoted = true
+		}
+	}
+	if !sawCallEdge {
+		t.Error("Parse did not emit a call edge for `greet World`")
+	}
+	if !sawUnquoted {
+		t.Error("Parse did not flag the unquoted $UNQUOTED expansion")
+	}
+}
+
+// TestShBackendMissingShebang documents the flag the request asked for.
+func TestShBackendMissingShebang(t *testing.T) {
+	b, err := backend.For(".sh")
+	if err != nil {
+		t.Fatalf("backend.For(\".sh\"): %v", err)
+	}
+
+	unit, err := b.Parse(context.Background(), "noshebang.sh", []byte("echo hi\n"))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(unit.Nodes) == 0 || unit.Nodes[0].Meta["missing_shebang"] != "true" {
+		t.Error("Parse did not flag a script with no shebang")
+	}
+}
+
+// TestShBackendSkipsBuiltinAndExternalCalls asserts that calls to
+// anything other than a function defined in the script — builtins,
+// external binaries — produce no edge, since there's no corresponding
+// function node for them to point at. None of its calls has an unquoted
+// expansion, so no flagged call-site node is emitted either.
+func TestShBackendSkipsBuiltinAndExternalCalls(t *testing.T) {
+	b, err := backend.For(".sh")
+	if err != nil {
+		t.Fatalf("backend.For(\".sh\"): %v", err)
+	}
+
+	const src = `#!/bin/sh
+echo hi
+cd /tmp
+export FOO=bar
+curl https://example.com
+`
+	unit, err := b.Parse(context.Background(), "plain.sh", []byte(src))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if len(unit.Edges) != 0 {
+		t.Errorf("Edges = %v, want none (no local functions defined)", unit.Edges)
+	}
+	for _, n := range unit.Nodes {
+		if n.Kind == ir.NodeFunction {
+			t.Errorf("unexpected function node for a builtin/external call: %+v", n)
+		}
+	}
+}
+
+// TestShBackendCallTargetResolutionIsSyntacticOnly documents the known
+// gap called out in Backend's doc comment: a variable used as a command
+// isn't resolved to the function it happens to name at runtime, since
+// there's no interp-based evaluation.
+func TestShBackendCallTargetResolutionIsSyntacticOnly(t *testing.T) {
+	b, err := backend.For(".sh")
+	if err != nil {
+		t.Fatalf("backend.For(\".sh\"): %v", err)
+	}
+
+	const src = `greet() {
+  echo hi
+}
+
+cmd=greet
+$cmd
+`
+	unit, err := b.Parse(context.Background(), "indirect.sh", []byte(src))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	for _, e := range unit.Edges {
+		if e.To == "indirect.sh#greet" {
+			t.Error("call via a variable resolved to the function node; expected this to be unresolved without interp")
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Package ir defines the language-agnostic intermediate representation
+// every dotViewer backend parses source into. The dot renderer (and any
+// future JSON/TUI frontend) only ever walks a Unit, never a language's own
+// AST, so adding a backend doesn't touch the rendering side.
+package ir
+
+// NodeKind distinguishes the kinds of things a backend can emit as a
+// graph node.
+type NodeKind int
+
+const (
+	// NodeUnknown is the zero value; backends should not emit it.
+	NodeUnknown NodeKind = iota
+	// NodeFile represents a source file as a whole (e.g. a shell script
+	// sourced by another).
+	NodeFile
+	// NodeFunction represents a callable unit: a Go function/method or a
+	// shell function. Backends also use it for annotation nodes tied to
+	// code: gobackend's per-struct concurrency summary and shbackend's
+	// call sites flagged for an unquoted expansion.
+	NodeFunction
+)
+
+// Node is a single graph node contributed by a backend. Meta carries
+// backend-specific annotations (e.g. "unquoted_expansion": "true") that
+// the dot renderer can use to color or flag the node.
+type Node struct {
+	ID   string
+	Name string
+	Kind NodeKind
+	Meta map[string]string
+}
+
+// Edge is a directed relationship between two nodes, e.g. a call site or
+// a sourced file. From/To refer to Node.ID values within the same Unit.
+type Edge struct {
+	From string
+	To   string
+	Meta map[string]string
+}
+
+// Unit is everything a backend extracted from a single source file.
+type Unit struct {
+	Path  string
+	Nodes []Node
+	Edges []Edge
+}
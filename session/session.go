@@ -0,0 +1,81 @@
+// Package session tracks per-browser state for server-mode dotViewer, where
+// multiple users explore the same (or different) graphs concurrently. The
+// HTTP layer looks a session up by cookie and mutates it under the store's
+// write lock, mirroring how the analyzer's own UserStore fixture guards its
+// map with a sync.RWMutex.
+package session
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNoUser is returned by MeStore.Me when no user has been set yet.
+var ErrNoUser = errors.New("session: no user set for this session")
+
+// User is the state a single browser session owns: its current view of the
+// graph plus the source files it has uploaded for analysis.
+type User struct {
+	ID       string
+	Zoom     float64
+	Filter   string
+	Selected string   // currently selected package, if any
+	Sources  []string // paths of uploaded source files
+}
+
+// MeStore holds the User for a single session. Implementations must be
+// safe for concurrent use, since the HTTP layer may read and write the
+// same session from multiple in-flight requests.
+type MeStore interface {
+	// Me returns the session's current user, or ErrNoUser if none has
+	// been set.
+	Me() (*User, error)
+	// MyselfSet stores u as the session's user. If update is true and a
+	// user already exists, u replaces it; otherwise an existing user is
+	// left untouched.
+	MyselfSet(u User, update bool) error
+	// Reset clears the session's user.
+	Reset() error
+}
+
+// meStore is the default, in-memory MeStore implementation.
+type meStore struct {
+	mu   sync.RWMutex
+	user *User
+	set  bool
+}
+
+// NewMeStore returns a ready-to-use, in-memory MeStore for a single
+// session.
+func NewMeStore() MeStore {
+	return &meStore{}
+}
+
+func (s *meStore) Me() (*User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.set {
+		return nil, ErrNoUser
+	}
+	u := *s.user
+	return &u, nil
+}
+
+func (s *meStore) MyselfSet(u User, update bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.set && !update {
+		return nil
+	}
+	s.user = &u
+	s.set = true
+	return nil
+}
+
+func (s *meStore) Reset() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.user = nil
+	s.set = false
+	return nil
+}
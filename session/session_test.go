@@ -0,0 +1,93 @@
+package session
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestMeStoreNoUser(t *testing.T) {
+	s := NewMeStore()
+	if _, err := s.Me(); !errors.Is(err, ErrNoUser) {
+		t.Errorf("Me() on empty store = %v, want ErrNoUser", err)
+	}
+}
+
+func TestMeStoreSetAndGet(t *testing.T) {
+	s := NewMeStore()
+	if err := s.MyselfSet(User{ID: "u1", Zoom: 1}, false); err != nil {
+		t.Fatalf("MyselfSet: %v", err)
+	}
+	got, err := s.Me()
+	if err != nil {
+		t.Fatalf("Me: %v", err)
+	}
+	if got.ID != "u1" {
+		t.Errorf("Me().ID = %q, want %q", got.ID, "u1")
+	}
+}
+
+func TestMeStoreSetWithoutUpdateLeavesExisting(t *testing.T) {
+	s := NewMeStore()
+	if err := s.MyselfSet(User{ID: "first"}, false); err != nil {
+		t.Fatalf("MyselfSet: %v", err)
+	}
+	if err := s.MyselfSet(User{ID: "second"}, false); err != nil {
+		t.Fatalf("MyselfSet: %v", err)
+	}
+	got, err := s.Me()
+	if err != nil {
+		t.Fatalf("Me: %v", err)
+	}
+	if got.ID != "first" {
+		t.Errorf("Me().ID = %q, want %q (update=false should not overwrite)", got.ID, "first")
+	}
+}
+
+func TestMeStoreSetWithUpdateOverwrites(t *testing.T) {
+	s := NewMeStore()
+	if err := s.MyselfSet(User{ID: "first"}, false); err != nil {
+		t.Fatalf("MyselfSet: %v", err)
+	}
+	if err := s.MyselfSet(User{ID: "second"}, true); err != nil {
+		t.Fatalf("MyselfSet: %v", err)
+	}
+	got, err := s.Me()
+	if err != nil {
+		t.Fatalf("Me: %v", err)
+	}
+	if got.ID != "second" {
+		t.Errorf("Me().ID = %q, want %q (update=true should overwrite)", got.ID, "second")
+	}
+}
+
+func TestMeStoreReset(t *testing.T) {
+	s := NewMeStore()
+	if err := s.MyselfSet(User{ID: "u1"}, false); err != nil {
+		t.Fatalf("MyselfSet: %v", err)
+	}
+	if err := s.Reset(); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	if _, err := s.Me(); !errors.Is(err, ErrNoUser) {
+		t.Errorf("Me() after Reset = %v, want ErrNoUser", err)
+	}
+}
+
+// TestMeStoreConcurrentAccess exercises the RWMutex under -race.
+func TestMeStoreConcurrentAccess(t *testing.T) {
+	s := NewMeStore()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_ = s.MyselfSet(User{ID: "writer"}, true)
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, _ = s.Me()
+		}()
+	}
+	wg.Wait()
+}
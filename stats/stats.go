@@ -0,0 +1,121 @@
+// Package stats provides lightweight, concurrency-safe counters that
+// downstream tools (Prometheus exporters, JSON dumps, the TUI) can query
+// while dotViewer walks a repository.
+package stats
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// StatCounter is a single named, atomically-readable metric.
+type StatCounter interface {
+	// Value returns the counter's current value.
+	Value() int64
+	// Set overwrites the counter and returns the previous value.
+	Set(v int64) int64
+	// Add increments the counter by delta and returns the new value.
+	Add(delta int64) int64
+}
+
+// StatManager registers and looks up StatCounters by name. Implementations
+// must be safe for concurrent use, since counters are typically registered
+// and bumped from multiple goroutines during a multi-file walk.
+type StatManager interface {
+	// RegisterCounter creates (or returns the existing) counter for name.
+	RegisterCounter(name string) (StatCounter, error)
+	// GetCounter returns the counter for name, or nil if it was never
+	// registered.
+	GetCounter(name string) StatCounter
+	// Start prepares the manager for use, e.g. opening exporter endpoints.
+	Start() error
+	// Close releases any resources held by the manager and its exporters.
+	Close() error
+}
+
+// counter is the default StatCounter implementation. The manager's
+// RWMutex only protects the counters map itself; once a counter is handed
+// out via RegisterCounter/GetCounter it can be bumped concurrently from
+// many goroutines, so the value needs its own synchronization.
+type counter struct {
+	value atomic.Int64
+}
+
+func (c *counter) Value() int64 {
+	return c.value.Load()
+}
+
+func (c *counter) Set(v int64) int64 {
+	return c.value.Swap(v)
+}
+
+func (c *counter) Add(delta int64) int64 {
+	return c.value.Add(delta)
+}
+
+// manager is the default StatManager implementation. Counters are held in
+// a plain map guarded by an RWMutex, the same pattern the UserStore in
+// TestFiles/test.go uses for its map.
+type manager struct {
+	mu       sync.RWMutex
+	counters map[string]*counter
+	started  bool
+}
+
+// NewStatManager returns a ready-to-use, in-memory StatManager.
+func NewStatManager() StatManager {
+	return &manager{
+		counters: make(map[string]*counter),
+	}
+}
+
+func (m *manager) RegisterCounter(name string) (StatCounter, error) {
+	if name == "" {
+		return nil, fmt.Errorf("stats: counter name must not be empty")
+	}
+
+	m.mu.RLock()
+	if c, ok := m.counters[name]; ok {
+		m.mu.RUnlock()
+		return c, nil
+	}
+	m.mu.RUnlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c, ok := m.counters[name]; ok {
+		return c, nil
+	}
+	c := &counter{}
+	m.counters[name] = c
+	return c, nil
+}
+
+func (m *manager) GetCounter(name string) StatCounter {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, ok := m.counters[name]
+	if !ok {
+		return nil
+	}
+	return c
+}
+
+// Start marks the manager as active. It is idempotent so exporters can call
+// it defensively.
+func (m *manager) Start() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.started = true
+	return nil
+}
+
+// Close stops the manager. Counter values are left intact so a final
+// exporter pass (e.g. a JSON dump on shutdown) can still read them.
+func (m *manager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.started = false
+	return nil
+}
@@ -0,0 +1,65 @@
+package stats
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestCounterConcurrentAdd reproduces the data race a bare int64 field
+// had under concurrent Add calls: run with -race.
+func TestCounterConcurrentAdd(t *testing.T) {
+	sm := NewStatManager()
+	c, err := sm.RegisterCounter("files_parsed")
+	if err != nil {
+		t.Fatalf("RegisterCounter: %v", err)
+	}
+
+	const goroutines = 50
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			c.Add(1)
+		}()
+	}
+	wg.Wait()
+
+	if got, want := c.Value(), int64(goroutines); got != want {
+		t.Errorf("Value() = %d, want %d", got, want)
+	}
+}
+
+func TestRegisterCounterReturnsSameInstance(t *testing.T) {
+	sm := NewStatManager()
+	a, err := sm.RegisterCounter("structs_found")
+	if err != nil {
+		t.Fatalf("RegisterCounter: %v", err)
+	}
+	a.Set(3)
+
+	b, err := sm.RegisterCounter("structs_found")
+	if err != nil {
+		t.Fatalf("RegisterCounter (second call): %v", err)
+	}
+	if got := b.Value(); got != 3 {
+		t.Errorf("second RegisterCounter returned a distinct counter: Value() = %d, want 3", got)
+	}
+	if got := sm.GetCounter("structs_found").Value(); got != 3 {
+		t.Errorf("GetCounter returned a distinct counter: Value() = %d, want 3", got)
+	}
+}
+
+func TestGetCounterUnknown(t *testing.T) {
+	sm := NewStatManager()
+	if c := sm.GetCounter("nope"); c != nil {
+		t.Errorf("GetCounter(unregistered) = %v, want nil", c)
+	}
+}
+
+func TestRegisterCounterEmptyName(t *testing.T) {
+	sm := NewStatManager()
+	if _, err := sm.RegisterCounter(""); err == nil {
+		t.Error("RegisterCounter(\"\") should error")
+	}
+}